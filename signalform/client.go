@@ -0,0 +1,128 @@
+package signalform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRequestTimeout    = 60 * time.Second
+	defaultMaxRetries        = 4
+	defaultMinRetryDelay     = 1 * time.Second
+	defaultRequestsPerSecond = 10.0
+)
+
+// apiClient bundles the *http.Client and retry/rate-limit policy used for every SignalFx request.
+type apiClient struct {
+	httpClient    *http.Client
+	limiter       *tokenBucket
+	maxRetries    int
+	minRetryDelay time.Duration
+}
+
+func newAPIClient(maxRetries int, minRetryDelay time.Duration, requestsPerSecond float64) *apiClient {
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+	if minRetryDelay <= 0 {
+		minRetryDelay = defaultMinRetryDelay
+	}
+	if requestsPerSecond <= 0 {
+		requestsPerSecond = defaultRequestsPerSecond
+	}
+	return &apiClient{
+		httpClient:    &http.Client{Timeout: defaultRequestTimeout},
+		limiter:       newTokenBucket(requestsPerSecond),
+		maxRetries:    maxRetries,
+		minRetryDelay: minRetryDelay,
+	}
+}
+
+// sendRequest rate-limits and retries an http call to SignalFx with exponential backoff, retrying
+// on connection errors and on 429/502/503/504, honoring Retry-After when present. Any other status
+// code is returned as-is for the caller to turn into an APIError.
+func sendRequest(ctx context.Context, client *apiClient, method string, url string, token string, payload []byte) (int, []byte, error) {
+	var (
+		statusCode int
+		body       []byte
+		err        error
+	)
+
+	for attempt := 0; ; attempt++ {
+		if waitErr := client.limiter.Wait(ctx); waitErr != nil {
+			return -1, nil, waitErr
+		}
+
+		var retryAfter time.Duration
+		statusCode, body, retryAfter, err = client.doOnce(ctx, method, url, token, payload)
+
+		retryable := err != nil || isRetryableStatus(statusCode)
+		if !retryable || attempt >= client.maxRetries {
+			break
+		}
+
+		delay := retryAfter
+		if delay <= 0 {
+			delay = client.minRetryDelay * time.Duration(uint(1)<<uint(attempt))
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return -1, nil, ctx.Err()
+		}
+	}
+
+	if err != nil {
+		return -1, nil, fmt.Errorf("Failed sending %s request to Signalfx: %s", method, err.Error())
+	}
+	return statusCode, body, nil
+}
+
+func (c *apiClient) doOnce(ctx context.Context, method string, url string, token string, payload []byte) (int, []byte, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+	if err != nil {
+		return -1, nil, 0, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("X-SF-Token", token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return -1, nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, 0, err
+	}
+	return resp.StatusCode, body, parseRetryAfter(resp.Header.Get("Retry-After")), nil
+}
+
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
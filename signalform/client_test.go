@@ -0,0 +1,201 @@
+package signalform
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIsRetryableStatus(t *testing.T) {
+	cases := []struct {
+		statusCode int
+		want       bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusNotFound, false},
+		{http.StatusBadRequest, false},
+		{http.StatusTooManyRequests, true},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusGatewayTimeout, true},
+	}
+	for _, tc := range cases {
+		if got := isRetryableStatus(tc.statusCode); got != tc.want {
+			t.Errorf("isRetryableStatus(%d) = %v, want %v", tc.statusCode, got, tc.want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "empty", value: "", want: 0},
+		{name: "invalid", value: "not-a-duration", want: 0},
+		{name: "seconds", value: "2", want: 2 * time.Second},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := parseRetryAfter(tc.value); got != tc.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tc.value, got, tc.want)
+			}
+		})
+	}
+
+	t.Run("http-date", func(t *testing.T) {
+		future := time.Now().Add(5 * time.Second).UTC()
+		got := parseRetryAfter(future.Format(http.TimeFormat))
+		if got <= 0 || got > 5*time.Second {
+			t.Errorf("parseRetryAfter(%q) = %v, want a positive duration close to 5s", future, got)
+		}
+	})
+}
+
+func TestSendRequest_RetriesUntilSuccess(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "abc"}`))
+	}))
+	defer server.Close()
+
+	client := newAPIClient(4, time.Millisecond, 1000)
+	statusCode, _, err := sendRequest(context.Background(), client, "GET", server.URL, "token", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statusCode != http.StatusOK {
+		t.Errorf("statusCode = %d, want %d", statusCode, http.StatusOK)
+	}
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("made %d requests, want 3 (2 failures + 1 success)", got)
+	}
+}
+
+func TestSendRequest_GivesUpAfterMaxRetries(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newAPIClient(2, time.Millisecond, 1000)
+	statusCode, _, err := sendRequest(context.Background(), client, "GET", server.URL, "token", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statusCode != http.StatusServiceUnavailable {
+		t.Errorf("statusCode = %d, want %d", statusCode, http.StatusServiceUnavailable)
+	}
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("made %d requests, want 3 (1 initial + 2 retries)", got)
+	}
+}
+
+func TestSendRequest_NonRetryableStatusReturnsImmediately(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := newAPIClient(4, time.Millisecond, 1000)
+	statusCode, _, err := sendRequest(context.Background(), client, "GET", server.URL, "token", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("statusCode = %d, want %d", statusCode, http.StatusBadRequest)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("made %d requests, want 1 (no retry on non-retryable status)", got)
+	}
+}
+
+func TestSendRequest_RetryAfterOverridesBackoff(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "abc"}`))
+	}))
+	defer server.Close()
+
+	// minRetryDelay is large; if the 1s Retry-After weren't honored over the exponential backoff
+	// (which would wait the full minRetryDelay on the first retry), this would take much longer.
+	client := newAPIClient(2, 5*time.Second, 1000)
+	start := time.Now()
+	statusCode, _, err := sendRequest(context.Background(), client, "GET", server.URL, "token", nil)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statusCode != http.StatusOK {
+		t.Errorf("statusCode = %d, want %d", statusCode, http.StatusOK)
+	}
+	if elapsed >= 5*time.Second {
+		t.Errorf("elapsed = %v, want well under the 5s backoff delay since the 1s Retry-After should be honored", elapsed)
+	}
+}
+
+func TestSendRequest_ContextCancellationMidWait(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newAPIClient(5, 5*time.Second, 1000)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, _, err := sendRequest(ctx, client, "GET", server.URL, "token", nil)
+	if err == nil {
+		t.Fatalf("expected an error from context cancellation mid-retry-wait, got nil")
+	}
+}
+
+func TestNewAPIError_ParsesStructuredBody(t *testing.T) {
+	err := newAPIError(404, []byte(`{"code": "NOT_FOUND", "message": "no such chart", "requestId": "req-1"}`))
+	if err.Code != "NOT_FOUND" || err.Message != "no such chart" || err.RequestID != "req-1" {
+		t.Errorf("got %+v, want parsed code/message/requestId", err)
+	}
+	if !err.IsNotFound() {
+		t.Errorf("expected IsNotFound() to be true for status 404")
+	}
+}
+
+func TestNewAPIError_MalformedBody(t *testing.T) {
+	err := newAPIError(500, []byte("not json"))
+	if err.Code != "" || err.Message != "" || err.RequestID != "" {
+		t.Errorf("got %+v, want all fields blank for an unparseable body", err)
+	}
+	if err.Error() == "" {
+		t.Errorf("expected Error() to still produce a message from the raw body")
+	}
+}
+
+func TestNewAPIError_EmptyBody(t *testing.T) {
+	err := newAPIError(503, nil)
+	if err.Code != "" || err.Message != "" || err.RequestID != "" {
+		t.Errorf("got %+v, want all fields blank for an empty body", err)
+	}
+	if err.Error() == "" {
+		t.Errorf("expected Error() to still produce a message for an empty body")
+	}
+}
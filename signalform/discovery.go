@@ -0,0 +1,79 @@
+package signalform
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// discoveryTTL bounds how long a resolved set of endpoints is reused before being recomputed. A
+// single `terraform apply` can issue hundreds of requests; without a cache each one would redo the
+// same realm-to-URL resolution.
+const discoveryTTL = 15 * time.Minute
+
+// realmAPIHost returns the api host for a known SignalFx realm, e.g. "eu0" -> "api.eu0.signalfx.com".
+// "us0" (and the empty realm, for backward compatibility) maps to the original un-prefixed host.
+func realmAPIHost(realm string) string {
+	if realm == "" || realm == "us0" {
+		return "api.signalfx.com"
+	}
+	return fmt.Sprintf("api.%s.signalfx.com", realm)
+}
+
+// Endpoints holds the resolved base URLs for every SignalFx API this provider talks to.
+type Endpoints struct {
+	ChartAPIURL        string
+	DashboardAPIURL    string
+	DetectorAPIURL     string
+	TeamAPIURL         string
+	OrganizationAPIURL string
+}
+
+// resolveEndpoints computes the Endpoints for a provider configuration. customAPIURL, when set,
+// overrides realm-based discovery entirely (used for proxies and air-gapped installs); otherwise
+// the host is derived from realm.
+func resolveEndpoints(realm string, customAPIURL string) *Endpoints {
+	host := realmAPIHost(realm)
+	base := fmt.Sprintf("https://%s", host)
+	if customAPIURL != "" {
+		base = strings.TrimRight(customAPIURL, "/")
+	}
+	return &Endpoints{
+		ChartAPIURL:        base + "/v2/chart",
+		DashboardAPIURL:    base + "/v2/dashboard",
+		DetectorAPIURL:     base + "/v2/detector",
+		TeamAPIURL:         base + "/v2/team",
+		OrganizationAPIURL: base + "/v2/organization",
+	}
+}
+
+// discoveryCache memoizes resolveEndpoints results keyed by (realm, customAPIURL) for discoveryTTL.
+type discoveryCache struct {
+	mu      sync.Mutex
+	entries map[string]discoveryCacheEntry
+}
+
+type discoveryCacheEntry struct {
+	endpoints *Endpoints
+	expiresAt time.Time
+}
+
+func newDiscoveryCache() *discoveryCache {
+	return &discoveryCache{entries: map[string]discoveryCacheEntry{}}
+}
+
+func (c *discoveryCache) Resolve(realm string, customAPIURL string) *Endpoints {
+	key := realm + "|" + customAPIURL
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok && time.Now().Before(entry.expiresAt) {
+		return entry.endpoints
+	}
+
+	endpoints := resolveEndpoints(realm, customAPIURL)
+	c.entries[key] = discoveryCacheEntry{endpoints: endpoints, expiresAt: time.Now().Add(discoveryTTL)}
+	return endpoints
+}
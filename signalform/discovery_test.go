@@ -0,0 +1,102 @@
+package signalform
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRealmAPIHost(t *testing.T) {
+	cases := []struct {
+		realm string
+		want  string
+	}{
+		{realm: "", want: "api.signalfx.com"},
+		{realm: "us0", want: "api.signalfx.com"},
+		{realm: "eu0", want: "api.eu0.signalfx.com"},
+		{realm: "us1", want: "api.us1.signalfx.com"},
+	}
+	for _, tc := range cases {
+		if got := realmAPIHost(tc.realm); got != tc.want {
+			t.Errorf("realmAPIHost(%q) = %q, want %q", tc.realm, got, tc.want)
+		}
+	}
+}
+
+func TestResolveEndpoints(t *testing.T) {
+	t.Run("realm-based", func(t *testing.T) {
+		endpoints := resolveEndpoints("eu0", "")
+		want := "https://api.eu0.signalfx.com"
+		if endpoints.ChartAPIURL != want+"/v2/chart" {
+			t.Errorf("ChartAPIURL = %q, want %q", endpoints.ChartAPIURL, want+"/v2/chart")
+		}
+		if endpoints.OrganizationAPIURL != want+"/v2/organization" {
+			t.Errorf("OrganizationAPIURL = %q, want %q", endpoints.OrganizationAPIURL, want+"/v2/organization")
+		}
+	})
+
+	t.Run("custom API URL overrides realm", func(t *testing.T) {
+		endpoints := resolveEndpoints("eu0", "https://proxy.internal/")
+		want := "https://proxy.internal/v2/chart"
+		if endpoints.ChartAPIURL != want {
+			t.Errorf("ChartAPIURL = %q, want %q", endpoints.ChartAPIURL, want)
+		}
+	})
+}
+
+func TestDiscoveryCache_ReusesBeforeTTL(t *testing.T) {
+	c := newDiscoveryCache()
+
+	first := c.Resolve("eu0", "")
+	second := c.Resolve("eu0", "")
+	if first != second {
+		t.Fatalf("expected cached entry to be reused before TTL expiry")
+	}
+}
+
+func TestDiscoveryCache_RecomputesAfterTTL(t *testing.T) {
+	c := newDiscoveryCache()
+
+	first := c.Resolve("eu0", "")
+	key := "eu0|"
+	entry := c.entries[key]
+	entry.expiresAt = time.Now().Add(-time.Second)
+	c.entries[key] = entry
+
+	second := c.Resolve("eu0", "")
+	if first == second {
+		t.Fatalf("expected a new *Endpoints to be computed after TTL expiry")
+	}
+}
+
+func TestDiscoveryCache_KeysDoNotCollide(t *testing.T) {
+	c := newDiscoveryCache()
+
+	realmEndpoints := c.Resolve("eu0", "")
+	customEndpoints := c.Resolve("", "https://eu0")
+
+	if realmEndpoints == customEndpoints {
+		t.Fatalf("expected realm and custom-URL cache entries to be distinct")
+	}
+}
+
+func TestDiscoveryCache_ConcurrentResolve(t *testing.T) {
+	c := newDiscoveryCache()
+
+	var wg sync.WaitGroup
+	results := make([]*Endpoints, 50)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = c.Resolve("us1", "")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, got := range results {
+		if got != results[0] {
+			t.Fatalf("expected all concurrent resolves to return the same cached *Endpoints")
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package signalform
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// APIError represents a non-2xx response from the SignalFx API, exposing the status code plus
+// whatever code/message/requestId fields the body parsed into.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("SignalFx API returned status %d (%s): %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("SignalFx API returned status %d: %s", e.StatusCode, string(e.Body))
+}
+
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == 404
+}
+
+func (e *APIError) IsUnauthorized() bool {
+	return e.StatusCode == 401 || e.StatusCode == 403
+}
+
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == 429
+}
+
+// newAPIError builds an APIError from a non-2xx SignalFx response, best-effort parsing the
+// `{"code": "...", "message": "...", "requestId": "..."}` body and leaving unparseable fields blank.
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Body: body}
+
+	var parsed struct {
+		Code      string `json:"code"`
+		Message   string `json:"message"`
+		RequestID string `json:"requestId"`
+	}
+	if json.Unmarshal(body, &parsed) == nil {
+		apiErr.Code = parsed.Code
+		apiErr.Message = parsed.Message
+		apiErr.RequestID = parsed.RequestID
+	}
+	return apiErr
+}
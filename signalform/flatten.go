@@ -0,0 +1,52 @@
+package signalform
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+// resourceFlattener maps a raw SignalFx API response into the ResourceData for a single managed
+// resource; each resource type supplies its own to resourceRead.
+type resourceFlattener func(remote map[string]interface{}, d *schema.ResourceData) error
+
+// flattenColorScaleOptions is the inverse of getColorScaleOptions: given the color scale object
+// SignalFx returns, it rebuilds the shape the color_scale set expects.
+func flattenColorScaleOptions(options map[string]interface{}) []map[string]interface{} {
+	if options == nil {
+		return nil
+	}
+	thresholdsRaw, _ := options["thresholds"].([]interface{})
+	thresholds := make([]interface{}, len(thresholdsRaw))
+	for i, t := range thresholdsRaw {
+		if f, ok := t.(float64); ok {
+			thresholds[i] = int(f)
+		}
+	}
+	inverted, _ := options["inverted"].(bool)
+	return []map[string]interface{}{
+		{
+			"thresholds": thresholds,
+			"inverted":   inverted,
+		},
+	}
+}
+
+// flattenLegendOptions is the inverse of getLegendOptions: given the legendOptions object
+// SignalFx returns, it rebuilds the legend_fields_to_hide set of property names.
+func flattenLegendOptions(options map[string]interface{}) []interface{} {
+	if options == nil {
+		return nil
+	}
+	fieldsRaw, _ := options["fields"].([]interface{})
+	hidden := make([]interface{}, 0, len(fieldsRaw))
+	for _, f := range fieldsRaw {
+		field, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if enabled, _ := field["enabled"].(bool); enabled {
+			continue
+		}
+		if property, ok := field["property"].(string); ok {
+			hidden = append(hidden, property)
+		}
+	}
+	return hidden
+}
@@ -0,0 +1,46 @@
+package signalform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// PlatformInfo is the org/realm a validated auth token resolves to.
+type PlatformInfo struct {
+	OrgID string
+	Realm string
+}
+
+// checkPlatformCompatibility validates the configured token against /v2/organization and records
+// which org it belongs to.
+func checkPlatformCompatibility(ctx context.Context, client *apiClient, endpoints *Endpoints, authToken string, realm string) (*PlatformInfo, diag.Diagnostics) {
+	status_code, resp_body, err := sendRequest(ctx, client, "GET", endpoints.OrganizationAPIURL, authToken, nil)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	if status_code != 200 {
+		apiErr := newAPIError(status_code, resp_body)
+		summary := "Failed to validate SignalFx platform compatibility"
+		if apiErr.IsUnauthorized() {
+			summary = "SignalFx auth token is invalid or lacks the scope required to manage these resources"
+		}
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   apiErr.Error(),
+		}}
+	}
+
+	var org struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(resp_body, &org); err != nil {
+		return nil, diag.FromErr(fmt.Errorf("Failed unmarshaling organization response during platform check: %s", err.Error()))
+	}
+
+	return &PlatformInfo{OrgID: org.ID, Realm: realm}, nil
+}
@@ -0,0 +1,58 @@
+package signalform
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckPlatformCompatibility(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantErr    bool
+		wantOrgID  string
+	}{
+		{
+			name:       "valid token",
+			statusCode: http.StatusOK,
+			body:       `{"id": "org-123"}`,
+			wantOrgID:  "org-123",
+		},
+		{
+			name:       "expired token",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"code": "UNAUTHORIZED", "message": "token expired"}`,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.statusCode)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			endpoints := &Endpoints{OrganizationAPIURL: server.URL}
+			client := newAPIClient(defaultMaxRetries, defaultMinRetryDelay, defaultRequestsPerSecond)
+
+			platform, diags := checkPlatformCompatibility(context.Background(), client, endpoints, "token", "us0")
+			if tc.wantErr {
+				if !diags.HasError() {
+					t.Fatalf("expected an error diagnostic, got none")
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error diagnostics: %v", diags)
+			}
+			if platform.OrgID != tc.wantOrgID {
+				t.Errorf("got org id %q, want %q", platform.OrgID, tc.wantOrgID)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package signalform
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Provider returns the *schema.Provider for Signalform. It is the single factory used by the
+// standalone plugin binary (plugin.Serve) and by callers that drive the provider in-process, so
+// both paths build the same resource map and configuration.
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"auth_token": {
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SFX_AUTH_TOKEN", nil),
+				Description: "SignalFx auth token",
+			},
+			"realm": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SFX_REALM", "us0"),
+				Description: "SignalFx realm to use (e.g. us0, eu0, us1, ap0). Ignored if api_url is set.",
+			},
+			"api_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SFX_API_URL", ""),
+				Description: "Custom base URL for the SignalFx API, overriding realm-based discovery.",
+			},
+			"custom_app_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Custom base URL for the SignalFx web UI. It is kept on the provider configuration and is not used for API requests.",
+			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     defaultMaxRetries,
+				Description: "Maximum number of retries for a request that fails with a transient error (429/502/503/504 or a connection error). A value of 0 or less uses the default.",
+			},
+			"min_retry_delay": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     int(defaultMinRetryDelay / time.Second),
+				Description: "Delay, in seconds, before the first retry; each later retry doubles it. When the API sends Retry-After, that delay is used instead for that retry. A value of 0 or less uses the default.",
+			},
+			"requests_per_second": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Default:     defaultRequestsPerSecond,
+				Description: "Maximum number of requests per second this provider instance will send to SignalFx across all resources.",
+			},
+			"skip_platform_check": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SFX_SKIP_PLATFORM_CHECK", false),
+				Description: "Skip the startup token/org validation against the SignalFx API. Useful for air-gapped or proxied setups where /v2/organization is unreachable.",
+			},
+		},
+		ResourcesMap:         map[string]*schema.Resource{},
+		ConfigureContextFunc: providerConfigure,
+	}
+}
+
+// providerDiscoveryCache is shared across all ConfigureContextFunc invocations in the process so
+// that, e.g., acceptance tests that instantiate the provider repeatedly still benefit from the TTL.
+var providerDiscoveryCache = newDiscoveryCache()
+
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	realm := d.Get("realm").(string)
+	apiURL := d.Get("api_url").(string)
+	authToken := d.Get("auth_token").(string)
+
+	config := &signalformConfig{
+		AuthToken:    authToken,
+		CustomAppURL: d.Get("custom_app_url").(string),
+		Endpoints:    providerDiscoveryCache.Resolve(realm, apiURL),
+		Client: newAPIClient(
+			d.Get("max_retries").(int),
+			time.Duration(d.Get("min_retry_delay").(int))*time.Second,
+			d.Get("requests_per_second").(float64),
+		),
+	}
+
+	if d.Get("skip_platform_check").(bool) {
+		return config, nil
+	}
+
+	platform, diags := checkPlatformCompatibility(ctx, config.Client, config.Endpoints, authToken, realm)
+	if diags.HasError() {
+		return nil, diags
+	}
+	config.Platform = platform
+	return config, diags
+}
+
+// signalformConfig is the per-provider state handed to every resource through the CRUD `meta` argument.
+type signalformConfig struct {
+	AuthToken    string
+	CustomAppURL string
+	Endpoints    *Endpoints
+	Client       *apiClient
+	Platform     *PlatformInfo
+}
@@ -0,0 +1,85 @@
+package signalform
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestProviderConfigure(t *testing.T) {
+	cases := []struct {
+		name              string
+		orgStatusCode     int
+		orgBody           string
+		skipPlatformCheck bool
+		wantErr           bool
+		wantPlatformNil   bool
+	}{
+		{
+			name:          "valid token sets Platform",
+			orgStatusCode: http.StatusOK,
+			orgBody:       `{"id": "org-123"}`,
+		},
+		{
+			name:              "skip_platform_check bypasses the org lookup entirely",
+			orgStatusCode:     http.StatusUnauthorized,
+			orgBody:           `{"code": "UNAUTHORIZED", "message": "token expired"}`,
+			skipPlatformCheck: true,
+			wantPlatformNil:   true,
+		},
+		{
+			name:          "failing platform check short-circuits with an error diagnostic",
+			orgStatusCode: http.StatusUnauthorized,
+			orgBody:       `{"code": "UNAUTHORIZED", "message": "token expired"}`,
+			wantErr:       true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.orgStatusCode)
+				w.Write([]byte(tc.orgBody))
+			}))
+			defer server.Close()
+
+			raw := map[string]interface{}{
+				"auth_token":          "token",
+				"api_url":             server.URL,
+				"skip_platform_check": tc.skipPlatformCheck,
+				"max_retries":         defaultMaxRetries,
+				"min_retry_delay":     int(defaultMinRetryDelay.Seconds()),
+				"requests_per_second": defaultRequestsPerSecond,
+			}
+			d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+
+			meta, diags := providerConfigure(context.Background(), d)
+			if tc.wantErr {
+				if !diags.HasError() {
+					t.Fatalf("expected an error diagnostic, got none")
+				}
+				if meta != nil {
+					t.Errorf("expected nil config on error, got %+v", meta)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error diagnostics: %v", diags)
+			}
+
+			config, ok := meta.(*signalformConfig)
+			if !ok {
+				t.Fatalf("expected *signalformConfig, got %T", meta)
+			}
+			if tc.wantPlatformNil && config.Platform != nil {
+				t.Errorf("expected Platform to be nil when skip_platform_check is set, got %+v", config.Platform)
+			}
+			if !tc.wantPlatformNil && config.Platform == nil {
+				t.Errorf("expected Platform to be populated from the platform check")
+			}
+		})
+	}
+}
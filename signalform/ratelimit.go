@@ -0,0 +1,53 @@
+package signalform
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// tokenBucket is a minimal token-bucket rate limiter shared by an apiClient's requests.
+type tokenBucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	maxTokens  float64
+	refillRate float64 // tokens per second
+	lastRefill time.Time
+}
+
+func newTokenBucket(requestsPerSecond float64) *tokenBucket {
+	// The bucket holds at least one token so that rates below 1 request/second can still be served.
+	capacity := math.Max(1, requestsPerSecond)
+	return &tokenBucket{
+		tokens:     capacity,
+		maxTokens:  capacity,
+		refillRate: requestsPerSecond,
+		lastRefill: time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done, whichever comes first.
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		elapsed := now.Sub(b.lastRefill).Seconds()
+		b.tokens = math.Min(b.maxTokens, b.tokens+elapsed*b.refillRate)
+		b.lastRefill = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.refillRate * float64(time.Second))
+		b.mu.Unlock()
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
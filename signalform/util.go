@@ -1,12 +1,10 @@
 package signalform
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/hashicorp/terraform/helper/schema"
-	"io/ioutil"
-	"net/http"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"regexp"
 	"sort"
 	"strconv"
@@ -14,10 +12,6 @@ import (
 )
 
 const (
-	// Workaround for Signalfx bug related to post processing and lastUpdatedTime
-	OFFSET        = 10000.0
-	CHART_API_URL = "https://api.signalfx.com/v2/chart"
-
 	// Colors
 	GRAY       = "#999999"
 	BLUE       = "#0077c2"
@@ -32,31 +26,6 @@ const (
 	AQUAMARINE = "#0dba8f"
 )
 
-/*
-  Utility function that wraps http calls to SignalFx
-*/
-func sendRequest(method string, url string, token string, payload []byte) (int, []byte, error) {
-	client := &http.Client{}
-
-	req, err := http.NewRequest(method, url, bytes.NewReader(payload))
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("X-SF-Token", token)
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return -1, nil, fmt.Errorf("Failed sending %s request to Signalfx: %s", method, err.Error())
-	}
-
-	body, err := ioutil.ReadAll(resp.Body)
-	defer resp.Body.Close()
-
-	if err != nil {
-		return resp.StatusCode, nil, fmt.Errorf("Failed reading response body from %s request: %s", method, err.Error())
-	}
-
-	return resp.StatusCode, body, nil
-}
-
 /*
   Validates the color_range field against a list of allowed words.
 */
@@ -110,42 +79,49 @@ func getColorScaleOptions(d *schema.ResourceData) map[string]interface{} {
 }
 
 /*
-  Send a GET to get the current state of the resource. It just checks if the lastUpdated timestamp is
-  later than the timestamp saved in the resource. If so, the resource has been modified in some way
-  in the UI, and should be recreated. This is signaled by setting synced to false, meaning if synced is set to
-  true in the tf configuration, it will update the resource to achieve the desired state.
+  Send a GET for the current state of the resource and reconcile every managed attribute into d
+  via the supplied flatten, so Terraform's own plan diff -- not a last_updated timestamp -- is
+  what surfaces drift between the SignalFx UI and the Terraform configuration. last_updated is
+  still recorded but is purely informational now.
 */
-func resourceRead(url string, sfxToken string, d *schema.ResourceData) error {
-	status_code, resp_body, err := sendRequest("GET", url, sfxToken, nil)
-	if status_code == 200 {
-		mapped_resp := map[string]interface{}{}
-		err = json.Unmarshal(resp_body, &mapped_resp)
-		if err != nil {
-			return fmt.Errorf("Failed unmarshaling for the resource %s during read: %s", d.Get("name"), err.Error())
-		}
-		// This implies the resource was modified in the Signalfx UI and therefore it is not synced with Signalform
-		last_updated := mapped_resp["lastUpdated"].(float64)
-		if last_updated > (d.Get("last_updated").(float64) + OFFSET) {
-			d.Set("synced", false)
-			d.Set("last_updated", last_updated)
-		}
-	} else {
-		if strings.Contains(string(resp_body), "Resource not found") {
+func resourceRead(ctx context.Context, client *apiClient, url string, sfxToken string, d *schema.ResourceData, flatten resourceFlattener) error {
+	status_code, resp_body, err := sendRequest(ctx, client, "GET", url, sfxToken, nil)
+	if err != nil {
+		return err
+	}
+	if status_code != 200 {
+		apiErr := newAPIError(status_code, resp_body)
+		if apiErr.IsNotFound() {
 			// This implies that the resouce was deleted in the Signalfx UI and therefore we need to recreate it
 			d.SetId("")
-		} else {
-			return fmt.Errorf("For the resource %s SignalFx returned status %d: \n%s", d.Get("name"), status_code, resp_body)
+			return nil
 		}
+		return apiErr
 	}
 
-	return nil
+	remote := map[string]interface{}{}
+	if err := json.Unmarshal(resp_body, &remote); err != nil {
+		return fmt.Errorf("Failed unmarshaling for the resource %s during read: %s", d.Get("name"), err.Error())
+	}
+
+	if lastUpdated, ok := remote["lastUpdated"].(float64); ok {
+		d.Set("last_updated", lastUpdated)
+	}
+
+	if flatten == nil {
+		return nil
+	}
+	return flatten(remote, d)
 }
 
 /*
   Fetches payload specified in terraform configuration and creates a resource
 */
-func resourceCreate(url string, sfxToken string, payload []byte, d *schema.ResourceData) error {
-	status_code, resp_body, err := sendRequest("POST", url, sfxToken, payload)
+func resourceCreate(ctx context.Context, client *apiClient, url string, sfxToken string, payload []byte, d *schema.ResourceData) error {
+	status_code, resp_body, err := sendRequest(ctx, client, "POST", url, sfxToken, payload)
+	if err != nil {
+		return err
+	}
 	if status_code == 200 {
 		mapped_resp := map[string]interface{}{}
 		err = json.Unmarshal(resp_body, &mapped_resp)
@@ -154,9 +130,8 @@ func resourceCreate(url string, sfxToken string, payload []byte, d *schema.Resou
 		}
 		d.SetId(fmt.Sprintf("%s", mapped_resp["id"].(string)))
 		d.Set("last_updated", mapped_resp["lastUpdated"].(float64))
-		d.Set("synced", true)
 	} else {
-		return fmt.Errorf("For the resource %s SignalFx returned status %d: \n%s", d.Get("name"), status_code, resp_body)
+		return newAPIError(status_code, resp_body)
 	}
 	return nil
 }
@@ -164,19 +139,20 @@ func resourceCreate(url string, sfxToken string, payload []byte, d *schema.Resou
 /*
   Fetches payload specified in terraform configuration and creates chart
 */
-func resourceUpdate(url string, sfxToken string, payload []byte, d *schema.ResourceData) error {
-	status_code, resp_body, err := sendRequest("PUT", url, sfxToken, payload)
+func resourceUpdate(ctx context.Context, client *apiClient, url string, sfxToken string, payload []byte, d *schema.ResourceData) error {
+	status_code, resp_body, err := sendRequest(ctx, client, "PUT", url, sfxToken, payload)
+	if err != nil {
+		return err
+	}
 	if status_code == 200 {
 		mapped_resp := map[string]interface{}{}
 		err = json.Unmarshal(resp_body, &mapped_resp)
 		if err != nil {
 			return fmt.Errorf("Failed unmarshaling for the resource %s during creation: %s", d.Get("name"), err.Error())
 		}
-		// If the resource was updated successfully with Signalform configs, it is now synced with Signalfx
-		d.Set("synced", true)
 		d.Set("last_updated", mapped_resp["lastUpdated"].(float64))
 	} else {
-		return fmt.Errorf("For the resource %s SignalFx returned status %d: \n%s", d.Get("name"), status_code, resp_body)
+		return newAPIError(status_code, resp_body)
 	}
 	return nil
 }
@@ -184,15 +160,15 @@ func resourceUpdate(url string, sfxToken string, payload []byte, d *schema.Resou
 /*
   Deletes a resource.  If the resource does not exist, it will receive a 404, and carry on as usual.
 */
-func resourceDelete(url string, sfxToken string, d *schema.ResourceData) error {
-	status_code, resp_body, err := sendRequest("DELETE", url, sfxToken, nil)
+func resourceDelete(ctx context.Context, client *apiClient, url string, sfxToken string, d *schema.ResourceData) error {
+	status_code, resp_body, err := sendRequest(ctx, client, "DELETE", url, sfxToken, nil)
 	if err != nil {
 		return fmt.Errorf("Failed deleting resource  %s: %s", d.Get("name"), err.Error())
 	}
 	if status_code < 400 || status_code == 404 {
 		d.SetId("")
 	} else {
-		return fmt.Errorf("For the resource  %s SignalFx returned status %d: \n%s", d.Get("name"), status_code, resp_body)
+		return newAPIError(status_code, resp_body)
 	}
 	return nil
 }
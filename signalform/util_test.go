@@ -0,0 +1,390 @@
+package signalform
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testResourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name":         {Type: schema.TypeString, Optional: true},
+		"description":  {Type: schema.TypeString, Optional: true},
+		"program_text": {Type: schema.TypeString, Optional: true},
+		"time_range":   {Type: schema.TypeString, Optional: true},
+		"last_updated": {Type: schema.TypeFloat, Optional: true},
+		"color_scale": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"thresholds": {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeInt}},
+					"inverted":   {Type: schema.TypeBool, Optional: true},
+				},
+			},
+		},
+		"legend_fields_to_hide": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+func testFlatten(remote map[string]interface{}, d *schema.ResourceData) error {
+	fieldToRemoteKey := map[string]string{
+		"name":         "name",
+		"description":  "description",
+		"program_text": "programText",
+		"time_range":   "timeRange",
+	}
+	for field, remoteKey := range fieldToRemoteKey {
+		if v, ok := remote[remoteKey]; ok {
+			d.Set(field, v)
+		}
+	}
+	if colorScale, ok := remote["colorScale2"].(map[string]interface{}); ok {
+		d.Set("color_scale", flattenColorScaleOptions(colorScale))
+	}
+	if legendOptions, ok := remote["legendOptions"].(map[string]interface{}); ok {
+		d.Set("legend_fields_to_hide", flattenLegendOptions(legendOptions))
+	}
+	return nil
+}
+
+/*
+TestResourceRead_FieldLevelDiff mutates a single field at a time in the server-side response and
+asserts that resourceRead surfaces only that field changing, instead of the old behavior of
+flipping a single "synced" bool for any drift.
+*/
+func TestResourceRead_FieldLevelDiff(t *testing.T) {
+	base := map[string]interface{}{
+		"id":          "abc123",
+		"name":        "original-name",
+		"description": "original-description",
+		"programText": "original-program",
+		"lastUpdated": float64(1000),
+	}
+
+	cases := []struct {
+		field       string
+		remoteField string
+		newValue    string
+	}{
+		{field: "name", remoteField: "name", newValue: "mutated-name"},
+		{field: "description", remoteField: "description", newValue: "mutated-description"},
+		{field: "program_text", remoteField: "programText", newValue: "mutated-program"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.field, func(t *testing.T) {
+			remote := map[string]interface{}{}
+			for k, v := range base {
+				remote[k] = v
+			}
+			remote[tc.remoteField] = tc.newValue
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(remote)
+			}))
+			defer server.Close()
+
+			d := schema.TestResourceDataRaw(t, testResourceSchema(), map[string]interface{}{
+				"name":         "original-name",
+				"description":  "original-description",
+				"program_text": "original-program",
+				"last_updated": float64(1000),
+			})
+
+			client := newAPIClient(defaultMaxRetries, defaultMinRetryDelay, defaultRequestsPerSecond)
+			if err := resourceRead(context.Background(), client, server.URL, "token", d, testFlatten); err != nil {
+				t.Fatalf("resourceRead returned error: %s", err)
+			}
+
+			originals := map[string]string{
+				"name":         "original-name",
+				"description":  "original-description",
+				"program_text": "original-program",
+			}
+			for _, field := range []string{"name", "description", "program_text"} {
+				got := d.Get(field).(string)
+				want := originals[field]
+				if field == tc.field {
+					want = tc.newValue
+				}
+				if got != want {
+					t.Errorf("field %s: got %q, want %q", field, got, want)
+				}
+			}
+		})
+	}
+}
+
+/*
+  TestResourceRead_FieldLevelDiff_VizOptions exercises the two non-trivial flatteners
+  (flattenColorScaleOptions, flattenLegendOptions) through the same resourceRead path real
+  resources use, mutating one viz-option field server-side at a time and asserting only that
+  field's value in ResourceData changes.
+*/
+func TestResourceRead_FieldLevelDiff_VizOptions(t *testing.T) {
+	baseConfig := map[string]interface{}{
+		"name":         "original-name",
+		"description":  "original-description",
+		"program_text": "original-program",
+		"time_range":   "-1h",
+		"last_updated": float64(1000),
+		"color_scale": []interface{}{
+			map[string]interface{}{
+				"thresholds": []interface{}{90, 10},
+				"inverted":   false,
+			},
+		},
+		"legend_fields_to_hide": []interface{}{"host"},
+	}
+
+	baseRemote := func() map[string]interface{} {
+		return map[string]interface{}{
+			"id":          "abc123",
+			"name":        "original-name",
+			"description": "original-description",
+			"programText": "original-program",
+			"timeRange":   "-1h",
+			"lastUpdated": float64(1000),
+			"colorScale2": map[string]interface{}{
+				"thresholds": []interface{}{float64(90), float64(10)},
+				"inverted":   false,
+			},
+			"legendOptions": map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{"property": "host", "enabled": false},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name         string
+		mutateRemote func(remote map[string]interface{})
+		assert       func(t *testing.T, d *schema.ResourceData)
+	}{
+		{
+			name: "time_range changes",
+			mutateRemote: func(remote map[string]interface{}) {
+				remote["timeRange"] = "-30m"
+			},
+			assert: func(t *testing.T, d *schema.ResourceData) {
+				if got := d.Get("time_range").(string); got != "-30m" {
+					t.Errorf("time_range: got %q, want %q", got, "-30m")
+				}
+				assertUnchangedBasicFields(t, d)
+			},
+		},
+		{
+			name: "color_scale thresholds change",
+			mutateRemote: func(remote map[string]interface{}) {
+				remote["colorScale2"] = map[string]interface{}{
+					"thresholds": []interface{}{float64(95), float64(5)},
+					"inverted":   false,
+				}
+			},
+			assert: func(t *testing.T, d *schema.ResourceData) {
+				thresholds := flattenedThresholds(t, d)
+				want := []int{95, 5}
+				if !equalIntSlices(thresholds, want) {
+					t.Errorf("color_scale thresholds: got %v, want %v", thresholds, want)
+				}
+				if got := d.Get("time_range").(string); got != "-1h" {
+					t.Errorf("time_range should be unchanged, got %q", got)
+				}
+			},
+		},
+		{
+			name: "color_scale inverted flips",
+			mutateRemote: func(remote map[string]interface{}) {
+				remote["colorScale2"] = map[string]interface{}{
+					"thresholds": []interface{}{float64(90), float64(10)},
+					"inverted":   true,
+				}
+			},
+			assert: func(t *testing.T, d *schema.ResourceData) {
+				inverted := flattenedInverted(t, d)
+				if !inverted {
+					t.Errorf("color_scale inverted: got false, want true")
+				}
+				assertUnchangedBasicFields(t, d)
+			},
+		},
+		{
+			name: "legend_fields_to_hide gains a hidden property",
+			mutateRemote: func(remote map[string]interface{}) {
+				remote["legendOptions"] = map[string]interface{}{
+					"fields": []interface{}{
+						map[string]interface{}{"property": "host", "enabled": false},
+						map[string]interface{}{"property": "env", "enabled": false},
+					},
+				}
+			},
+			assert: func(t *testing.T, d *schema.ResourceData) {
+				hidden := d.Get("legend_fields_to_hide").(*schema.Set).List()
+				if len(hidden) != 2 {
+					t.Fatalf("legend_fields_to_hide: got %v, want 2 entries", hidden)
+				}
+				assertUnchangedBasicFields(t, d)
+			},
+		},
+		{
+			name: "legend_fields_to_hide loses a hidden property (enabled flips true)",
+			mutateRemote: func(remote map[string]interface{}) {
+				remote["legendOptions"] = map[string]interface{}{
+					"fields": []interface{}{
+						map[string]interface{}{"property": "host", "enabled": true},
+					},
+				}
+			},
+			assert: func(t *testing.T, d *schema.ResourceData) {
+				hidden := d.Get("legend_fields_to_hide").(*schema.Set).List()
+				if len(hidden) != 0 {
+					t.Fatalf("legend_fields_to_hide: got %v, want 0 entries", hidden)
+				}
+				assertUnchangedBasicFields(t, d)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			remote := baseRemote()
+			tc.mutateRemote(remote)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(remote)
+			}))
+			defer server.Close()
+
+			d := schema.TestResourceDataRaw(t, testResourceSchema(), baseConfig)
+
+			client := newAPIClient(defaultMaxRetries, defaultMinRetryDelay, defaultRequestsPerSecond)
+			if err := resourceRead(context.Background(), client, server.URL, "token", d, testFlatten); err != nil {
+				t.Fatalf("resourceRead returned error: %s", err)
+			}
+
+			tc.assert(t, d)
+		})
+	}
+}
+
+func flattenedThresholds(t *testing.T, d *schema.ResourceData) []int {
+	t.Helper()
+	set := d.Get("color_scale").(*schema.Set).List()
+	if len(set) != 1 {
+		t.Fatalf("expected exactly one color_scale entry, got %d", len(set))
+	}
+	raw := set[0].(map[string]interface{})["thresholds"].([]interface{})
+	thresholds := make([]int, len(raw))
+	for i, v := range raw {
+		thresholds[i] = v.(int)
+	}
+	return thresholds
+}
+
+func flattenedInverted(t *testing.T, d *schema.ResourceData) bool {
+	t.Helper()
+	set := d.Get("color_scale").(*schema.Set).List()
+	if len(set) != 1 {
+		t.Fatalf("expected exactly one color_scale entry, got %d", len(set))
+	}
+	return set[0].(map[string]interface{})["inverted"].(bool)
+}
+
+func equalIntSlices(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func assertUnchangedBasicFields(t *testing.T, d *schema.ResourceData) {
+	t.Helper()
+	if got := d.Get("name").(string); got != "original-name" {
+		t.Errorf("name should be unchanged, got %q", got)
+	}
+	if got := d.Get("description").(string); got != "original-description" {
+		t.Errorf("description should be unchanged, got %q", got)
+	}
+	if got := d.Get("program_text").(string); got != "original-program" {
+		t.Errorf("program_text should be unchanged, got %q", got)
+	}
+}
+
+func TestFlattenColorScaleOptions(t *testing.T) {
+	cases := []struct {
+		name   string
+		remote map[string]interface{}
+		want   []map[string]interface{}
+	}{
+		{
+			name: "ascending thresholds, not inverted",
+			remote: map[string]interface{}{
+				"thresholds": []interface{}{float64(10), float64(90)},
+				"inverted":   false,
+			},
+			want: []map[string]interface{}{
+				{"thresholds": []interface{}{10, 90}, "inverted": false},
+			},
+		},
+		{
+			name:   "nil options",
+			remote: nil,
+			want:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := flattenColorScaleOptions(tc.remote)
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d entries, want %d", len(got), len(tc.want))
+			}
+			for i := range got {
+				gotThresholds, _ := got[i]["thresholds"].([]interface{})
+				wantThresholds, _ := tc.want[i]["thresholds"].([]interface{})
+				if len(gotThresholds) != len(wantThresholds) {
+					t.Fatalf("thresholds length mismatch: got %v, want %v", gotThresholds, wantThresholds)
+				}
+				for j := range gotThresholds {
+					if gotThresholds[j] != wantThresholds[j] {
+						t.Errorf("threshold %d: got %v, want %v", j, gotThresholds[j], wantThresholds[j])
+					}
+				}
+				if got[i]["inverted"] != tc.want[i]["inverted"] {
+					t.Errorf("inverted: got %v, want %v", got[i]["inverted"], tc.want[i]["inverted"])
+				}
+			}
+		})
+	}
+}
+
+func TestFlattenLegendOptions(t *testing.T) {
+	remote := map[string]interface{}{
+		"fields": []interface{}{
+			map[string]interface{}{"property": "host", "enabled": false},
+			map[string]interface{}{"property": "env", "enabled": true},
+		},
+	}
+
+	got := flattenLegendOptions(remote)
+	if len(got) != 1 || got[0] != "host" {
+		t.Fatalf("expected only the disabled property 'host' to be hidden, got %v", got)
+	}
+}